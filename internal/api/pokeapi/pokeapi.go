@@ -0,0 +1,61 @@
+// Package pokeapi contains the response shapes returned by
+// https://pokeapi.co/api/v2, trimmed down to the fields the pokedex CLI
+// actually uses.
+package pokeapi
+
+// NamedAPIResource is PokeAPI's standard {name, url} reference to
+// another resource.
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// LocationAreaListResponse is the paginated result of GET
+// /location-area/.
+type LocationAreaListResponse struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+// LocationArea is the result of GET /location-area/{name}.
+type LocationArea struct {
+	Name              string                  `json:"name"`
+	PokemonEncounters []PokemonEncounterEntry `json:"pokemon_encounters"`
+}
+
+// PokemonEncounterEntry is one Pokemon known to spawn in a LocationArea.
+type PokemonEncounterEntry struct {
+	Pokemon NamedAPIResource `json:"pokemon"`
+}
+
+// Pokemon is the result of GET /pokemon/{name}.
+type Pokemon struct {
+	Name           string        `json:"name"`
+	ID             int           `json:"id"`
+	BaseExperience int           `json:"base_experience"`
+	Height         int           `json:"height"`
+	Weight         int           `json:"weight"`
+	Stats          []PokemonStat `json:"stats"`
+	Types          []PokemonType `json:"types"`
+}
+
+// PokemonStat is one of a Pokemon's base stats (hp, attack, defense,
+// special-attack, special-defense, speed).
+type PokemonStat struct {
+	BaseStat int              `json:"base_stat"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+// PokemonType is one of the elemental types a Pokemon has.
+type PokemonType struct {
+	Slot int              `json:"slot"`
+	Type NamedAPIResource `json:"type"`
+}
+
+// PokemonEncounter is one entry of GET /pokemon/{name}/encounters,
+// naming a location area the Pokemon can be found in.
+type PokemonEncounter struct {
+	LocationArea NamedAPIResource `json:"location_area"`
+}
@@ -0,0 +1,143 @@
+// Package commands provides a small registry for CLI commands: name and
+// alias lookup, argument-count validation, typo suggestions, and
+// auto-generated help text.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Unbounded marks a command's MaxArgs as having no upper limit.
+const Unbounded = -1
+
+// Callback runs a Command once its argument count has been validated.
+type Callback func(ctx context.Context, args []string) error
+
+// Command describes a single CLI command.
+type Command struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Usage       string
+	MinArgs     int
+	MaxArgs     int // Unbounded for no upper limit
+	Callback    Callback
+}
+
+// Registry looks up commands by name or alias and dispatches to them.
+type Registry struct {
+	byName map[string]*Command
+	all    []*Command
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*Command)}
+}
+
+// Register adds cmd, indexed under its name and every alias.
+func (r *Registry) Register(cmd Command) {
+	c := cmd
+	r.all = append(r.all, &c)
+	r.byName[c.Name] = &c
+	for _, alias := range c.Aliases {
+		r.byName[alias] = &c
+	}
+}
+
+// Dispatch looks up name, validates args against the command's argument
+// count, and runs its callback. Unknown names return an error that
+// suggests the closest registered command name, if any.
+func (r *Registry) Dispatch(ctx context.Context, name string, args []string) error {
+	cmd, ok := r.byName[name]
+	if !ok {
+		return r.unknownCommandError(name)
+	}
+
+	if len(args) < cmd.MinArgs || (cmd.MaxArgs != Unbounded && len(args) > cmd.MaxArgs) {
+		return fmt.Errorf("usage: %s", cmd.Usage)
+	}
+
+	return cmd.Callback(ctx, args)
+}
+
+func (r *Registry) unknownCommandError(name string) error {
+	if suggestion := r.closestName(name); suggestion != "" {
+		return fmt.Errorf("unknown command: %s (did you mean %q?)", name, suggestion)
+	}
+	return fmt.Errorf("unknown command: %s", name)
+}
+
+// closestName returns the registered command name closest to name by
+// Levenshtein distance, or "" if nothing is close enough to be a
+// plausible typo.
+func (r *Registry) closestName(name string) string {
+	const maxSuggestDistance = 2
+
+	best := ""
+	bestDist := maxSuggestDistance + 1
+	for _, cmd := range r.all {
+		dist := levenshteinDistance(name, cmd.Name)
+		if dist < bestDist {
+			bestDist = dist
+			best = cmd.Name
+		}
+	}
+	if bestDist > maxSuggestDistance {
+		return ""
+	}
+	return best
+}
+
+// Help renders usage text for every registered command, in registration
+// order.
+func (r *Registry) Help() string {
+	var b strings.Builder
+	b.WriteString("Welcome to the Pokedex!\nUsage:\n\n")
+	for _, cmd := range r.all {
+		b.WriteString(fmt.Sprintf("%s: %s\n", cmd.Usage, cmd.Description))
+	}
+	return b.String()
+}
+
+// levenshteinDistance returns the edit distance between a and b.
+func levenshteinDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newTestRegistry(called *[]string) *Registry {
+	r := NewRegistry()
+	r.Register(Command{
+		Name:    "catch",
+		Aliases: []string{"c"},
+		Usage:   "catch <pokemon>",
+		MinArgs: 1,
+		MaxArgs: 1,
+		Callback: func(ctx context.Context, args []string) error {
+			*called = append(*called, "catch")
+			return nil
+		},
+	})
+	r.Register(Command{
+		Name:    "explore",
+		Usage:   "explore",
+		MinArgs: 0,
+		MaxArgs: 0,
+		Callback: func(ctx context.Context, args []string) error {
+			*called = append(*called, "explore")
+			return nil
+		},
+	})
+	r.Register(Command{
+		Name:    "give",
+		Usage:   "give <pokemon> [nickname]",
+		MinArgs: 1,
+		MaxArgs: 2,
+		Callback: func(ctx context.Context, args []string) error {
+			*called = append(*called, "give")
+			return nil
+		},
+	})
+	return r
+}
+
+func TestDispatchArgBoundaries(t *testing.T) {
+	var called []string
+	r := newTestRegistry(&called)
+
+	tests := []struct {
+		name    string
+		cmd     string
+		args    []string
+		wantErr bool
+	}{
+		{"below MinArgs", "catch", nil, true},
+		{"at MinArgs and MaxArgs", "catch", []string{"pikachu"}, false},
+		{"above MaxArgs", "catch", []string{"pikachu", "extra"}, true},
+		{"at MinArgs of a two-arg command", "give", []string{"pikachu"}, false},
+		{"at MaxArgs of a two-arg command", "give", []string{"pikachu", "sparky"}, false},
+		{"one past MaxArgs of a two-arg command", "give", []string{"pikachu", "sparky", "extra"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Dispatch(context.Background(), tt.cmd, tt.args)
+			if tt.wantErr && err == nil {
+				t.Errorf("Dispatch(%q, %v) error = nil, want an error", tt.cmd, tt.args)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Dispatch(%q, %v) error = %v, want nil", tt.cmd, tt.args, err)
+			}
+		})
+	}
+}
+
+func TestDispatchResolvesAlias(t *testing.T) {
+	var called []string
+	r := newTestRegistry(&called)
+
+	if err := r.Dispatch(context.Background(), "c", []string{"pikachu"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if len(called) != 1 || called[0] != "catch" {
+		t.Errorf("Dispatch() via alias \"c\" called = %v, want [\"catch\"]", called)
+	}
+}
+
+func TestDispatchUnknownCommandSuggestsClosestName(t *testing.T) {
+	var called []string
+	r := newTestRegistry(&called)
+
+	err := r.Dispatch(context.Background(), "explre", nil)
+	if err == nil {
+		t.Fatal("Dispatch() error = nil, want an error for an unknown command")
+	}
+	if !strings.Contains(err.Error(), `"explore"`) {
+		t.Errorf("Dispatch() error = %q, want it to suggest %q", err, "explore")
+	}
+}
+
+func TestClosestName(t *testing.T) {
+	var called []string
+	r := newTestRegistry(&called)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"one edit away from explore", "explre", "explore"},
+		{"two edits away from catch, still within threshold", "cacth", "catch"},
+		{"far beyond maxSuggestDistance returns no suggestion", "zzzzzzzzzz", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.closestName(tt.in)
+			if got != tt.want {
+				t.Errorf("closestName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package pokecache provides a small in-memory, time-expiring cache for
+// raw HTTP response bodies.
+package pokecache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores byte slices keyed by URL, evicting entries older than its
+// configured interval.
+type Cache struct {
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	interval time.Duration
+}
+
+type cacheEntry struct {
+	createdAt time.Time
+	val       []byte
+}
+
+// NewCache returns a Cache whose entries expire after interval and
+// starts the background reaper that sweeps them out.
+func NewCache(interval time.Duration) *Cache {
+	c := &Cache{
+		entries:  make(map[string]cacheEntry),
+		interval: interval,
+	}
+	go c.reapLoop()
+	return c
+}
+
+// Add stores val under key, timestamped with the current time.
+func (c *Cache) Add(key string, val []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		createdAt: time.Now(),
+		val:       val,
+	}
+}
+
+// Get returns the cached value for key, if present and not yet expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.createdAt) > c.interval {
+		return nil, false
+	}
+	return entry.val, true
+}
+
+func (c *Cache) reapLoop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.reap(time.Now(), c.interval)
+	}
+}
+
+func (c *Cache) reap(now time.Time, last time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if now.Sub(entry.createdAt) > last {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package pokeclient is a thin, caching HTTP client for the subset of
+// PokeAPI endpoints the pokedex CLI needs.
+package pokeclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/AungKyawPhyo1142/pokedex/internal/api/pokeapi"
+	"github.com/AungKyawPhyo1142/pokedex/internal/pokecache"
+)
+
+const baseURL = "https://pokeapi.co/api/v2/"
+
+// Client fetches PokeAPI resources, caching raw response bodies so
+// repeated lookups (e.g. re-visiting a location area) don't re-hit the
+// network.
+type Client struct {
+	httpClient *http.Client
+	cache      *pokecache.Cache
+}
+
+// New returns a Client whose cache entries expire after cacheInterval
+// and whose requests are aborted after timeout.
+func New(cacheInterval, timeout time.Duration) Client {
+	return Client{
+		httpClient: &http.Client{Timeout: timeout},
+		cache:      pokecache.NewCache(cacheInterval),
+	}
+}
+
+func (c *Client) get(ctx context.Context, url string, out any) error {
+	if val, ok := c.cache.Get(url); ok {
+		return json.Unmarshal(val, out)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 299 {
+		return fmt.Errorf("bad response from server: %s", res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	c.cache.Add(url, body)
+
+	return json.Unmarshal(body, out)
+}
+
+// GetLocationAreaList fetches a page of location areas. Pass "" to
+// fetch the first page, or the Next/Previous URL from a previous page
+// to paginate.
+func (c *Client) GetLocationAreaList(ctx context.Context, url string) (pokeapi.LocationAreaListResponse, error) {
+	if url == "" {
+		url = baseURL + "location-area/"
+	}
+
+	var out pokeapi.LocationAreaListResponse
+	err := c.get(ctx, url, &out)
+	return out, err
+}
+
+// GetLocationArea fetches the full details, including Pokemon
+// encounters, for a single location area.
+func (c *Client) GetLocationArea(ctx context.Context, name string) (pokeapi.LocationArea, error) {
+	url := baseURL + "location-area/" + name
+
+	var out pokeapi.LocationArea
+	err := c.get(ctx, url, &out)
+	return out, err
+}
+
+// GetPokemon fetches a Pokemon's base stats.
+func (c *Client) GetPokemon(ctx context.Context, name string) (pokeapi.Pokemon, error) {
+	url := baseURL + "pokemon/" + name
+
+	var out pokeapi.Pokemon
+	err := c.get(ctx, url, &out)
+	return out, err
+}
+
+// GetPokemonLocationAreas fetches the location areas a Pokemon can be
+// encountered in.
+func (c *Client) GetPokemonLocationAreas(ctx context.Context, path string) ([]pokeapi.PokemonEncounter, error) {
+	url := baseURL + "pokemon/" + path + "/encounters"
+
+	var out []pokeapi.PokemonEncounter
+	err := c.get(ctx, url, &out)
+	return out, err
+}
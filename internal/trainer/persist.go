@@ -0,0 +1,58 @@
+package trainer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPath returns the save-file location for the given profile,
+// honoring $XDG_DATA_HOME when set and falling back to
+// ~/.local/share/pokedex otherwise. An empty profile saves to
+// trainer.json.
+func DefaultPath(profile string) string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(os.Getenv("HOME"), ".local", "share")
+	}
+
+	fileName := "trainer.json"
+	if profile != "" {
+		fileName = profile + ".json"
+	}
+	return filepath.Join(dataHome, "pokedex", fileName)
+}
+
+// Save writes t as JSON to path, creating any missing parent
+// directories.
+func Save(t *Trainer, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load reads a previously saved Trainer from path. If path doesn't
+// exist yet, Load returns a fresh Trainer and no error.
+func Load(path string) (*Trainer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := New()
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,49 @@
+package trainer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/AungKyawPhyo1142/pokedex/internal/api/pokeapi"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trainer.json")
+
+	next := "https://pokeapi.co/api/v2/location-area/?offset=20"
+	original := New()
+	original.Catch(pokeapi.Pokemon{Name: "pikachu", ID: 25, BaseExperience: 112})
+	original.SetCurrentArea("viridian-forest", []string{"pikachu", "caterpie"})
+	original.NextLocationURL = &next
+
+	if err := Save(original, path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !loaded.HasCaught("pikachu") {
+		t.Errorf("Load() lost the Pokedex: HasCaught(\"pikachu\") = false")
+	}
+	if loaded.CurrentArea != original.CurrentArea {
+		t.Errorf("CurrentArea = %q, want %q", loaded.CurrentArea, original.CurrentArea)
+	}
+	if loaded.NextLocationURL == nil || *loaded.NextLocationURL != *original.NextLocationURL {
+		t.Errorf("NextLocationURL = %v, want %v", loaded.NextLocationURL, original.NextLocationURL)
+	}
+}
+
+func TestLoadMissingFileReturnsFreshTrainer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Pokedex) != 0 {
+		t.Errorf("Load() of a missing file returned a non-empty Pokedex")
+	}
+}
@@ -0,0 +1,42 @@
+package trainer
+
+import "github.com/AungKyawPhyo1142/pokedex/internal/api/pokeapi"
+
+// Trainer holds everything about the player's session that isn't
+// transport plumbing: the Pokedex of caught Pokemon, the pagination
+// cursors for browsing location areas, and the area the trainer is
+// currently standing in.
+type Trainer struct {
+	Pokedex map[string]pokeapi.Pokemon
+
+	NextLocationURL *string
+	PrevLocationURL *string
+
+	CurrentArea        string
+	CurrentAreaPokemon []string
+}
+
+// New returns an empty Trainer ready for a fresh session.
+func New() *Trainer {
+	return &Trainer{
+		Pokedex: make(map[string]pokeapi.Pokemon),
+	}
+}
+
+// Catch adds a Pokemon to the trainer's Pokedex.
+func (t *Trainer) Catch(p pokeapi.Pokemon) {
+	t.Pokedex[p.Name] = p
+}
+
+// HasCaught reports whether the named Pokemon is already in the Pokedex.
+func (t *Trainer) HasCaught(name string) bool {
+	_, ok := t.Pokedex[name]
+	return ok
+}
+
+// SetCurrentArea records the location area the trainer just visited,
+// along with the Pokemon known to spawn there.
+func (t *Trainer) SetCurrentArea(name string, pokemonNames []string) {
+	t.CurrentArea = name
+	t.CurrentAreaPokemon = pokemonNames
+}
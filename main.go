@@ -2,120 +2,62 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
-	"io"
 	"math/rand"
-	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/AungKyawPhyo1142/pokedex/internal/pokecache"
+	"github.com/AungKyawPhyo1142/pokedex/internal/commands"
+	"github.com/AungKyawPhyo1142/pokedex/internal/pokeclient"
+	"github.com/AungKyawPhyo1142/pokedex/internal/trainer"
 )
 
-const baseURL = "https://pokeapi.co/api/v2/"
-
-type cliCommand struct {
-	name        string
-	description string
-	callback    func(*config, []string) error
-}
+const (
+	cacheInterval  = 5 * time.Minute
+	requestTimeout = 10 * time.Second
+)
 
 type config struct {
-	nextURL *string
-	prevURL *string
-	cache   pokecache.Cache
-	pokedex map[string]PokemonInfo
-}
-
-type LocationAreaResponse struct {
-	Count    int     `json:"count"`
-	Next     *string `json:"next"`
-	Previous *string `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"results"`
-}
-
-type LocationAreaDetails struct {
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-		} `json:"pokemon"`
-	} `json:"pokemon_encounters"`
+	client   pokeclient.Client
+	trainer  *trainer.Trainer
+	savePath string
+	rng      *rand.Rand
 }
 
-type PokemonInfo struct {
-	Name           string `json:"name"`
-	ID             int    `json:"id"`
-	BaseExperience int    `json:"base_experience"`
-}
-
-func (c *config) fetchLocationArea(url string) (LocationAreaResponse, error) {
-
-	if val, ok := c.cache.Get(url); ok {
-		var locationAreaResponse LocationAreaResponse
-		err := json.Unmarshal(val, &locationAreaResponse)
-		if err != nil {
-			return LocationAreaResponse{}, err
-		}
-		return locationAreaResponse, nil
-	}
-
-	res, err := http.Get(url)
-	if err != nil {
-		return LocationAreaResponse{}, err
-	}
-	defer res.Body.Close()
+// computeCatchRate maps a Pokemon's base experience onto a catch
+// probability between 10% (tough, high base-exp Pokemon) and 90% (easy,
+// low base-exp Pokemon), pure so it can be tested without HTTP or RNG.
+func computeCatchRate(baseExp int) float64 {
+	maxExp := 600.0     // around Blissey's base exp
+	minCatchRate := 0.1 // 10% minimum chance
+	maxCatchRate := 0.9 // 90% maximum chance
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return LocationAreaResponse{}, err
-	}
-	c.cache.Add(url, body)
+	expRatio := float64(baseExp) / maxExp
+	rate := maxCatchRate - (expRatio * (maxCatchRate - minCatchRate))
 
-	var locationAreaResponse LocationAreaResponse
-	err = json.Unmarshal(body, &locationAreaResponse)
-	if err != nil {
-		return LocationAreaResponse{}, err
+	switch {
+	case rate < minCatchRate:
+		return minCatchRate
+	case rate > maxCatchRate:
+		return maxCatchRate
+	default:
+		return rate
 	}
-	return locationAreaResponse, nil
 }
 
-func (c *config) fetchPokemonInfo(pokemonName string) (PokemonInfo, error) {
-	fullURL := baseURL + "/pokemon/" + pokemonName
-
-	if val, ok := c.cache.Get(fullURL); ok {
-		var pokemonInfo PokemonInfo
-		err := json.Unmarshal(val, &pokemonInfo)
-		if err != nil {
-			return PokemonInfo{}, err
+// catchSeed returns the seed for the catch RNG: POKEDEX_SEED if set and
+// parseable, otherwise the current time for a fresh seed each run.
+func catchSeed() int64 {
+	if s := os.Getenv("POKEDEX_SEED"); s != "" {
+		if parsed, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return parsed
 		}
-		return pokemonInfo, nil
-	}
-
-	res, err := http.Get(fullURL)
-	if err != nil {
-		return PokemonInfo{}, err
 	}
-
-	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return PokemonInfo{}, err
-	}
-	c.cache.Add(fullURL, body)
-
-	var pokemon PokemonInfo
-	err = json.Unmarshal(body, &pokemon)
-	if err != nil {
-		return PokemonInfo{}, err
-	}
-
-	return pokemon, nil
-
+	return time.Now().UnixNano()
 }
 
 func cleanInput(text string) []string {
@@ -124,180 +66,281 @@ func cleanInput(text string) []string {
 	return words
 }
 
-func commandExit(c *config, args []string) error {
+func commandExit(ctx context.Context, c *config, args []string) error {
+	if err := trainer.Save(c.trainer, c.savePath); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not save trainer state: %v\n", err)
+	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(c *config, args []string) error {
-	text := `
-Welcome to the Pokedex!
-Usage:
-
-help: Displays a help message
-map: Displays the next 20 location areas
-mapb: Displays the previous 20 location areas
-explore <location_area>: Lists the pokemon in a given location area
-exit: Exit the Pokedex
-	`
-	fmt.Println(text)
-	return nil
-}
-
-func commandMap(c *config, args []string) error {
-	fullURL := baseURL + "location-area/"
-	if c.nextURL != nil {
-		fullURL = *c.nextURL
+func commandMap(ctx context.Context, c *config, args []string) error {
+	url := ""
+	if c.trainer.NextLocationURL != nil {
+		url = *c.trainer.NextLocationURL
 	}
-	data, err := c.fetchLocationArea(fullURL)
+	data, err := c.client.GetLocationAreaList(ctx, url)
 	if err != nil {
 		return err
 	}
-	c.nextURL = data.Next
-	c.prevURL = data.Previous
+	c.trainer.NextLocationURL = data.Next
+	c.trainer.PrevLocationURL = data.Previous
 
 	for _, loc := range data.Results {
 		fmt.Println(loc.Name)
 	}
 	return nil
-
 }
 
-func commandMapb(c *config, args []string) error {
-
-	if c.prevURL == nil {
+func commandMapb(ctx context.Context, c *config, args []string) error {
+	if c.trainer.PrevLocationURL == nil {
 		return fmt.Errorf("You are already at the first page")
 	}
 
-	url := *c.prevURL
-	data, err := c.fetchLocationArea(url)
+	data, err := c.client.GetLocationAreaList(ctx, *c.trainer.PrevLocationURL)
 	if err != nil {
 		return err
 	}
-	c.nextURL = data.Next
-	c.prevURL = data.Previous
+	c.trainer.NextLocationURL = data.Next
+	c.trainer.PrevLocationURL = data.Previous
 
 	for _, loc := range data.Results {
 		fmt.Println(loc.Name)
 	}
 	return nil
-
 }
 
-func commandExplore(c *config, args []string) error {
-	if len(args) != 1 {
-		return fmt.Errorf("you must provide a location area name")
-	}
+func commandVisit(ctx context.Context, c *config, args []string) error {
 	locationAreaName := args[0]
-	url := baseURL + "location-area/" + locationAreaName
-
-	body, ok := c.cache.Get(url)
-	if !ok {
-		res, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode > 299 {
-			return fmt.Errorf("bad response from server: %s", res.Status)
-		}
-		body, err = io.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		c.cache.Add(url, body)
-	}
 
-	var locationDetails LocationAreaDetails
-	err := json.Unmarshal(body, &locationDetails)
+	area, err := c.client.GetLocationArea(ctx, locationAreaName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Exploring %s...\n", locationAreaName)
-	fmt.Println("Found Pok√©mon:")
-	for _, encounter := range locationDetails.PokemonEncounters {
-		fmt.Printf(" - %s\n", encounter.Pokemon.Name)
+	pokemonNames := make([]string, 0, len(area.PokemonEncounters))
+	for _, encounter := range area.PokemonEncounters {
+		pokemonNames = append(pokemonNames, encounter.Pokemon.Name)
 	}
+	c.trainer.SetCurrentArea(locationAreaName, pokemonNames)
+
+	fmt.Printf("You are now standing in %s.\n", locationAreaName)
 	return nil
 }
 
-func commandCatch(c *config, args []string) error {
-	if len(args) != 1 {
-		return fmt.Errorf("you must provide a pokemon name")
+func commandExplore(ctx context.Context, c *config, args []string) error {
+	if c.trainer.CurrentArea == "" {
+		return fmt.Errorf("you are not currently at a location area, use visit <location_area> first")
+	}
+
+	fmt.Printf("Exploring %s...\n", c.trainer.CurrentArea)
+	fmt.Println("Found Pok√©mon:")
+	for _, name := range c.trainer.CurrentAreaPokemon {
+		fmt.Printf(" - %s\n", name)
 	}
+	return nil
+}
 
+func commandCatch(ctx context.Context, c *config, args []string) error {
 	pokemonName := args[0]
 
-	data, err := c.fetchPokemonInfo(pokemonName)
-	if err != nil {
-		return fmt.Errorf("error fetching pokemon info: %v", err)
+	if c.trainer.HasCaught(pokemonName) {
+		return fmt.Errorf("you've already caught a %s", pokemonName)
 	}
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
 
-	maxExp := 600.0     // around Blissey's base exp
-	minCatchRate := 0.1 // 10% minimum chance
-	maxCatchRate := 0.9 // 90% maximum chance
+	if c.trainer.CurrentArea == "" {
+		return fmt.Errorf("you are not currently at a location area, use visit <location_area> first")
+	}
+
+	encounters, err := c.client.GetPokemonLocationAreas(ctx, pokemonName)
+	if err != nil {
+		return fmt.Errorf("error fetching pokemon encounters: %v", err)
+	}
 
-	expRatio := float64(data.BaseExperience) / maxExp
-	catchRate := maxCatchRate - (expRatio * (maxCatchRate - minCatchRate))
+	appearsHere := false
+	for _, encounter := range encounters {
+		if encounter.LocationArea.Name == c.trainer.CurrentArea {
+			appearsHere = true
+			break
+		}
+	}
+	if !appearsHere {
+		return fmt.Errorf("%s does not appear in %s", pokemonName, c.trainer.CurrentArea)
+	}
 
-	rand.Seed(time.Now().UnixNano())
-	roll := rand.Float64()
+	data, err := c.client.GetPokemon(ctx, pokemonName)
+	if err != nil {
+		return fmt.Errorf("error fetching pokemon info: %v", err)
+	}
+	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
 
-	if roll < catchRate {
+	if roll := c.rng.Float64(); roll < computeCatchRate(data.BaseExperience) {
 		fmt.Printf("%s was caught!\n", pokemonName)
-		c.pokedex[pokemonName] = data // add to pokedex
+		c.trainer.Catch(data)
 	} else {
 		fmt.Printf("%s escaped!\n", pokemonName)
 	}
 
 	return nil
+}
+
+func commandPokedex(ctx context.Context, c *config, args []string) error {
+	if len(c.trainer.Pokedex) == 0 {
+		fmt.Println("Your Pokedex is empty. Go catch some Pokemon!")
+		return nil
+	}
 
+	fmt.Println("Your Pokedex:")
+	for name := range c.trainer.Pokedex {
+		fmt.Printf(" - %s\n", name)
+	}
+	return nil
 }
 
-func main() {
-	cfg := &config{
-		nextURL: nil,
-		prevURL: nil,
-		cache:   pokecache.NewCache(time.Minute * 5),
-		pokedex: map[string]PokemonInfo{},
+func commandInspect(ctx context.Context, c *config, args []string) error {
+	pokemonName := args[0]
+
+	p, ok := c.trainer.Pokedex[pokemonName]
+	if !ok {
+		return fmt.Errorf("you have not caught that pokemon")
 	}
 
-	commands := map[string]cliCommand{
-		"exit": {
-			name:        "exit",
-			description: "Exit the Pokedex",
-			callback:    commandExit,
+	fmt.Printf("Name: %s\n", p.Name)
+	fmt.Printf("Height: %d\n", p.Height)
+	fmt.Printf("Weight: %d\n", p.Weight)
+	fmt.Println("Stats:")
+	for _, stat := range p.Stats {
+		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+	}
+	fmt.Println("Types:")
+	for _, t := range p.Types {
+		fmt.Printf("  - %s\n", t.Type.Name)
+	}
+	return nil
+}
+
+// buildRegistry wires every CLI command up against cfg and returns the
+// registry the REPL dispatches against.
+func buildRegistry(cfg *config) *commands.Registry {
+	reg := commands.NewRegistry()
+
+	reg.Register(commands.Command{
+		Name:        "help",
+		Description: "Displays a help message",
+		Usage:       "help",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			fmt.Println(reg.Help())
+			return nil
+		},
+	})
+	reg.Register(commands.Command{
+		Name:        "map",
+		Description: "Display next 20 location areas",
+		Usage:       "map",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandMap(ctx, cfg, args)
 		},
-		"help": {
-			name:        "help",
-			description: "Displays a help message",
-			callback:    commandHelp,
+	})
+	reg.Register(commands.Command{
+		Name:        "mapb",
+		Description: "Display previous 20 location areas",
+		Usage:       "mapb",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandMapb(ctx, cfg, args)
 		},
-		"map": {
-			name:        "map",
-			description: "Display next 20 location areas",
-			callback:    commandMap,
+	})
+	reg.Register(commands.Command{
+		Name:        "visit",
+		Description: "Travel to a given location area",
+		Usage:       "visit <location_area>",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandVisit(ctx, cfg, args)
 		},
-		"mapb": {
-			name:        "mapb",
-			description: "Display previous 20 location areas",
-			callback:    commandMapb,
+	})
+	reg.Register(commands.Command{
+		Name:        "explore",
+		Description: "Explore the location area you're currently visiting",
+		Usage:       "explore",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandExplore(ctx, cfg, args)
 		},
-		"explore": {
-			name:        "explore",
-			description: "Explore a given location area",
-			callback:    commandExplore,
+	})
+	reg.Register(commands.Command{
+		Name:        "catch",
+		Description: "Attempt to catch a pokemon and add it to your pokedex",
+		Usage:       "catch <pokemon>",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandCatch(ctx, cfg, args)
 		},
-		"catch": {
-			name:        "catch",
-			description: "Attempt to catch a pokemon and add it to your pokedex",
-			callback:    commandCatch,
+	})
+	reg.Register(commands.Command{
+		Name:        "pokedex",
+		Description: "List all the pokemon you've caught",
+		Usage:       "pokedex",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandPokedex(ctx, cfg, args)
 		},
+	})
+	reg.Register(commands.Command{
+		Name:        "inspect",
+		Description: "Print details about a pokemon you've caught",
+		Usage:       "inspect <pokemon>",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandInspect(ctx, cfg, args)
+		},
+	})
+	reg.Register(commands.Command{
+		Name:        "exit",
+		Description: "Exit the Pokedex",
+		Usage:       "exit",
+		MinArgs:     0,
+		MaxArgs:     0,
+		Callback: func(ctx context.Context, args []string) error {
+			return commandExit(ctx, cfg, args)
+		},
+	})
+
+	return reg
+}
+
+func main() {
+	profile := flag.String("profile", os.Getenv("POKEDEX_PROFILE"), "trainer save-file profile to use")
+	flag.Parse()
+
+	savePath := trainer.DefaultPath(*profile)
+	tr, err := trainer.Load(savePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not load trainer state: %v\n", err)
+		tr = trainer.New()
+	}
+
+	cfg := &config{
+		client:   pokeclient.New(cacheInterval, requestTimeout),
+		trainer:  tr,
+		savePath: savePath,
+		rng:      rand.New(rand.NewSource(catchSeed())),
 	}
 
+	reg := buildRegistry(cfg)
+
+	ctx := context.Background()
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Print("Pokedex > ")
@@ -309,13 +352,7 @@ func main() {
 			continue
 		}
 
-		command, ok := commands[cleaned[0]]
-		if !ok {
-			fmt.Println("Unknown command")
-			continue
-		}
-
-		if err := command.callback(cfg, cleaned[1:]); err != nil {
+		if err := reg.Dispatch(ctx, cleaned[0], cleaned[1:]); err != nil {
 			fmt.Println(err)
 		}
 	}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestComputeCatchRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseExp int
+		want    float64
+	}{
+		{"zero base experience is the easiest catch", 0, 0.9},
+		{"blissey-level base experience is the hardest catch", 600, 0.1},
+		{"base experience beyond the reference max clamps to the floor", 1000, 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeCatchRate(tt.baseExp)
+			if got != tt.want {
+				t.Errorf("computeCatchRate(%d) = %v, want %v", tt.baseExp, got, tt.want)
+			}
+			if got < 0.1 || got > 0.9 {
+				t.Errorf("computeCatchRate(%d) = %v, want value within [0.1, 0.9]", tt.baseExp, got)
+			}
+		})
+	}
+}